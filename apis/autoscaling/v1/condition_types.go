@@ -0,0 +1,68 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionStatus is the status of a Condition, one of True, False or Unknown.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// ScheduledPodAutoscalerConditionType is a valid value for Condition.Type of a ScheduledPodAutoscaler.
+type ScheduledPodAutoscalerConditionType string
+
+const (
+	// AvailableScheduledPodAutoscalerCondition indicates whether the ScheduledPodAutoscaler
+	// is able to manage its target HorizontalPodAutoscaler.
+	AvailableScheduledPodAutoscalerCondition ScheduledPodAutoscalerConditionType = "Available"
+)
+
+// ScheduledPodAutoscalerStatusPhase is a high level summary of where the ScheduledPodAutoscaler is in its lifecycle.
+type ScheduledPodAutoscalerStatusPhase string
+
+const (
+	AvailableScheduledPodAutoscalerStatus   ScheduledPodAutoscalerStatusPhase = "Available"
+	UnavailableScheduledPodAutoscalerStatus ScheduledPodAutoscalerStatusPhase = "Unavailable"
+)
+
+// Condition describes the state of an object at a certain point.
+type Condition struct {
+	// Type of the condition.
+	Type string `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message indicating details about the last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
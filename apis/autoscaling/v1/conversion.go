@@ -0,0 +1,184 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+// ConvertHorizontalPodAutoscalerSpecToV2Beta2 downconverts a v2 HorizontalPodAutoscalerSpec to
+// v2beta2, for clusters older than Kubernetes 1.23 that do not serve autoscaling/v2. The two
+// versions have the same fields, but their nested types are distinct named types, so
+// ScaleTargetRef, MinReplicas, MaxReplicas and Metrics are converted one by one. Behavior is not
+// converted and is left unset in the result.
+func ConvertHorizontalPodAutoscalerSpecToV2Beta2(in autoscalingv2.HorizontalPodAutoscalerSpec) hpav2beta2.HorizontalPodAutoscalerSpec {
+	return hpav2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: hpav2beta2.CrossVersionObjectReference(in.ScaleTargetRef),
+		MinReplicas:    in.MinReplicas,
+		MaxReplicas:    in.MaxReplicas,
+		Metrics:        ConvertMetricSpecsToV2Beta2(in.Metrics),
+	}
+}
+
+// ConvertHorizontalPodAutoscalerSpecFromV2Beta2 upconverts a v2beta2 HorizontalPodAutoscalerSpec,
+// as read back from an older cluster or a pre-existing CR, to the canonical v2 shape. Behavior is
+// not converted and is left unset in the result.
+func ConvertHorizontalPodAutoscalerSpecFromV2Beta2(in hpav2beta2.HorizontalPodAutoscalerSpec) autoscalingv2.HorizontalPodAutoscalerSpec {
+	return autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference(in.ScaleTargetRef),
+		MinReplicas:    in.MinReplicas,
+		MaxReplicas:    in.MaxReplicas,
+		Metrics:        ConvertMetricSpecsFromV2Beta2(in.Metrics),
+	}
+}
+
+// convertMetricTargetToV2Beta2 converts a v2 MetricTarget to v2beta2. The two types have
+// identical fields but distinct named Type enums, so they cannot be converted with a single
+// struct conversion.
+func convertMetricTargetToV2Beta2(in autoscalingv2.MetricTarget) hpav2beta2.MetricTarget {
+	return hpav2beta2.MetricTarget{
+		Type:               hpav2beta2.MetricTargetType(in.Type),
+		Value:              in.Value,
+		AverageValue:       in.AverageValue,
+		AverageUtilization: in.AverageUtilization,
+	}
+}
+
+// convertMetricTargetFromV2Beta2 converts a v2beta2 MetricTarget to v2.
+func convertMetricTargetFromV2Beta2(in hpav2beta2.MetricTarget) autoscalingv2.MetricTarget {
+	return autoscalingv2.MetricTarget{
+		Type:               autoscalingv2.MetricTargetType(in.Type),
+		Value:              in.Value,
+		AverageValue:       in.AverageValue,
+		AverageUtilization: in.AverageUtilization,
+	}
+}
+
+// ConvertMetricSpecsToV2Beta2 downconverts a slice of v2 MetricSpecs to v2beta2.
+func ConvertMetricSpecsToV2Beta2(in []autoscalingv2.MetricSpec) []hpav2beta2.MetricSpec {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]hpav2beta2.MetricSpec, 0, len(in))
+
+	for _, m := range in {
+		converted := hpav2beta2.MetricSpec{Type: hpav2beta2.MetricSourceType(m.Type)}
+
+		switch m.Type {
+		case autoscalingv2.ResourceMetricSourceType:
+			if m.Resource == nil {
+				continue
+			}
+
+			converted.Resource = &hpav2beta2.ResourceMetricSource{
+				Name:   m.Resource.Name,
+				Target: convertMetricTargetToV2Beta2(m.Resource.Target),
+			}
+		case autoscalingv2.PodsMetricSourceType:
+			if m.Pods == nil {
+				continue
+			}
+
+			converted.Pods = &hpav2beta2.PodsMetricSource{
+				Metric: hpav2beta2.MetricIdentifier(m.Pods.Metric),
+				Target: convertMetricTargetToV2Beta2(m.Pods.Target),
+			}
+		case autoscalingv2.ObjectMetricSourceType:
+			if m.Object == nil {
+				continue
+			}
+
+			converted.Object = &hpav2beta2.ObjectMetricSource{
+				DescribedObject: hpav2beta2.CrossVersionObjectReference(m.Object.DescribedObject),
+				Metric:          hpav2beta2.MetricIdentifier(m.Object.Metric),
+				Target:          convertMetricTargetToV2Beta2(m.Object.Target),
+			}
+		case autoscalingv2.ExternalMetricSourceType:
+			if m.External == nil {
+				continue
+			}
+
+			converted.External = &hpav2beta2.ExternalMetricSource{
+				Metric: hpav2beta2.MetricIdentifier(m.External.Metric),
+				Target: convertMetricTargetToV2Beta2(m.External.Target),
+			}
+		}
+
+		out = append(out, converted)
+	}
+
+	return out
+}
+
+// ConvertMetricSpecsFromV2Beta2 upconverts a slice of v2beta2 MetricSpecs to v2.
+func ConvertMetricSpecsFromV2Beta2(in []hpav2beta2.MetricSpec) []autoscalingv2.MetricSpec {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]autoscalingv2.MetricSpec, 0, len(in))
+
+	for _, m := range in {
+		converted := autoscalingv2.MetricSpec{Type: autoscalingv2.MetricSourceType(m.Type)}
+
+		switch m.Type {
+		case hpav2beta2.ResourceMetricSourceType:
+			if m.Resource == nil {
+				continue
+			}
+
+			converted.Resource = &autoscalingv2.ResourceMetricSource{
+				Name:   m.Resource.Name,
+				Target: convertMetricTargetFromV2Beta2(m.Resource.Target),
+			}
+		case hpav2beta2.PodsMetricSourceType:
+			if m.Pods == nil {
+				continue
+			}
+
+			converted.Pods = &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier(m.Pods.Metric),
+				Target: convertMetricTargetFromV2Beta2(m.Pods.Target),
+			}
+		case hpav2beta2.ObjectMetricSourceType:
+			if m.Object == nil {
+				continue
+			}
+
+			converted.Object = &autoscalingv2.ObjectMetricSource{
+				DescribedObject: autoscalingv2.CrossVersionObjectReference(m.Object.DescribedObject),
+				Metric:          autoscalingv2.MetricIdentifier(m.Object.Metric),
+				Target:          convertMetricTargetFromV2Beta2(m.Object.Target),
+			}
+		case hpav2beta2.ExternalMetricSourceType:
+			if m.External == nil {
+				continue
+			}
+
+			converted.External = &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier(m.External.Metric),
+				Target: convertMetricTargetFromV2Beta2(m.External.Target),
+			}
+		}
+
+		out = append(out, converted)
+	}
+
+	return out
+}
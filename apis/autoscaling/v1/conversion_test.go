@@ -0,0 +1,111 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestConvertMetricSpecsToV2Beta2(t *testing.T) {
+	in := []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:   "cpu",
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(80)},
+			},
+		},
+		{
+			// A Pods MetricSpec whose Target.AverageValue is nil must survive without panicking.
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "packets-per-second"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+			},
+		},
+	}
+
+	got := ConvertMetricSpecsToV2Beta2(in)
+
+	want := []hpav2beta2.MetricSpec{
+		{
+			Type: hpav2beta2.ResourceMetricSourceType,
+			Resource: &hpav2beta2.ResourceMetricSource{
+				Name:   "cpu",
+				Target: hpav2beta2.MetricTarget{Type: hpav2beta2.UtilizationMetricType, AverageUtilization: int32Ptr(80)},
+			},
+		},
+		{
+			Type: hpav2beta2.PodsMetricSourceType,
+			Pods: &hpav2beta2.PodsMetricSource{
+				Metric: hpav2beta2.MetricIdentifier{Name: "packets-per-second"},
+				Target: hpav2beta2.MetricTarget{Type: hpav2beta2.AverageValueMetricType},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertMetricSpecsToV2Beta2() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertMetricSpecsFromV2Beta2(t *testing.T) {
+	in := []hpav2beta2.MetricSpec{
+		{
+			Type: hpav2beta2.ExternalMetricSourceType,
+			External: &hpav2beta2.ExternalMetricSource{
+				Metric: hpav2beta2.MetricIdentifier{Name: "queue-length"},
+				Target: hpav2beta2.MetricTarget{Type: hpav2beta2.ValueMetricType, Value: resourceQuantityPtr(100)},
+			},
+		},
+	}
+
+	got := ConvertMetricSpecsFromV2Beta2(in)
+
+	want := []autoscalingv2.MetricSpec{
+		{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: "queue-length"},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: resourceQuantityPtr(100)},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertMetricSpecsFromV2Beta2() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertMetricSpecsToV2Beta2NilInput(t *testing.T) {
+	if got := ConvertMetricSpecsToV2Beta2(nil); got != nil {
+		t.Errorf("ConvertMetricSpecsToV2Beta2(nil) = %v, want nil", got)
+	}
+}
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func resourceQuantityPtr(v int64) *resource.Quantity {
+	q := resource.NewQuantity(v, resource.DecimalSI)
+
+	return q
+}
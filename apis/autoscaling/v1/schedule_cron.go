@@ -0,0 +1,203 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser accepts both the traditional 5-field expression and an optional leading seconds
+// field, as well as the "@every"/"@daily" style descriptors.
+var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// cronLookback bounds how far back containsCron and previousCronTrigger search for the most
+// recent trigger before a given time. It must be larger than any realistic Duration.
+const cronLookback = 7 * 24 * time.Hour
+
+// minCronInterval is the shortest gap Validate permits between the first two firings of a Cron
+// expression after the Unix epoch. previousCronTrigger scans forward from t-cronLookback one firing at a time, so
+// without a floor a sub-minute expression (the optional seconds field allows e.g. "* * * * * *")
+// could take hundreds of thousands of steps to cover cronLookback.
+const minCronInterval = time.Minute
+
+// validateCronGranularity rejects Cron expressions whose first two firings after the Unix epoch
+// are less than minCronInterval apart. Later gaps between firings are not checked.
+func (s ScheduleSpec) validateCronGranularity() error {
+	schedule, err := cronParser.Parse(s.Cron)
+	if err != nil {
+		return err
+	}
+
+	first := schedule.Next(time.Unix(0, 0).UTC())
+	second := schedule.Next(first)
+
+	if d := second.Sub(first); d < minCronInterval {
+		return fmt.Errorf("must not fire more often than every %s, got an interval of %s", minCronInterval, d)
+	}
+
+	return nil
+}
+
+// duration returns the configured Duration, defaulting to one hour when unset.
+func (s ScheduleSpec) duration() time.Duration {
+	if s.Duration == nil {
+		return time.Hour
+	}
+
+	return s.Duration.Duration
+}
+
+// WindowDuration returns the length of the window this ScheduleSpec describes, used to break
+// priority ties in favor of the more specific (shorter) Schedule.
+func (s ScheduleSpec) WindowDuration() (time.Duration, error) {
+	if s.Cron != "" {
+		return s.duration(), nil
+	}
+
+	start, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return 0, err
+	}
+
+	d := end.Sub(start)
+	if d <= 0 {
+		d += 24 * time.Hour
+	}
+
+	return d, nil
+}
+
+// containsCron reports whether t falls within the Duration following the most recent Cron trigger.
+// t must already be converted to the Schedule's configured TimeZone.
+func (s ScheduleSpec) containsCron(t time.Time) (bool, error) {
+	prev, err := s.previousCronTrigger(t)
+	if err != nil {
+		return false, err
+	}
+
+	if prev.IsZero() {
+		return false, nil
+	}
+
+	return !t.Before(prev) && t.Before(prev.Add(s.duration())), nil
+}
+
+// previousCronTrigger returns the most recent time the Cron expression fired at or before t, or
+// the zero Time if it has not fired within cronLookback of t.
+func (s ScheduleSpec) previousCronTrigger(t time.Time) (time.Time, error) {
+	schedule, err := cronParser.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var prev time.Time
+
+	for cursor := t.Add(-cronLookback); ; {
+		next := schedule.Next(cursor)
+		if next.After(t) {
+			break
+		}
+
+		prev = next
+		cursor = next
+	}
+
+	return prev, nil
+}
+
+// nextCronTrigger returns the next time after t the Cron expression fires.
+func (s ScheduleSpec) nextCronTrigger(t time.Time) (time.Time, error) {
+	schedule, err := cronParser.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.Next(t), nil
+}
+
+// NextTransition returns the next time after t at which this Schedule's active state can change,
+// i.e. the next time a caller should re-evaluate Contains. For a Cron Schedule whose Duration is
+// longer than the gap between two triggers, the returned trigger can fall inside a window that is
+// already open, so the active state does not change there.
+func (s ScheduleSpec) NextTransition(t time.Time) (time.Time, error) {
+	loc, err := s.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t = t.In(loc)
+
+	if s.Cron != "" {
+		return s.nextCronTransition(t)
+	}
+
+	return s.nextWeeklyTransition(t)
+}
+
+func (s ScheduleSpec) nextCronTransition(t time.Time) (time.Time, error) {
+	next, err := s.nextCronTrigger(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	prev, err := s.previousCronTrigger(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !prev.IsZero() {
+		if end := prev.Add(s.duration()); end.After(t) && end.Before(next) {
+			return end, nil
+		}
+	}
+
+	return next, nil
+}
+
+func (s ScheduleSpec) nextWeeklyTransition(t time.Time) (time.Time, error) {
+	var next time.Time
+
+	// Start one day back so a window that opened yesterday and rolls over past midnight still
+	// contributes its end transition, matching the rollover tail Contains checks.
+	for days := -1; days <= 7; days++ {
+		day := t.AddDate(0, 0, days)
+		if day.Weekday() != time.Weekday(s.StartDayOfWeek) {
+			continue
+		}
+
+		start, end, err := s.weeklyWindow(day)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		for _, candidate := range []time.Time{start, end} {
+			if candidate.After(t) && (next.IsZero() || candidate.Before(next)) {
+				next = candidate
+			}
+		}
+	}
+
+	return next, nil
+}
@@ -0,0 +1,128 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func mustParseRFC3339(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("parse %q: %v", value, err)
+	}
+
+	return parsed
+}
+
+func TestScheduleSpecContainsCron(t *testing.T) {
+	spec := ScheduleSpec{Cron: "0 9 * * *", Duration: &metav1.Duration{Duration: time.Hour}}
+
+	tests := []struct {
+		name string
+		at   string
+		want bool
+	}{
+		{name: "before trigger", at: "2026-07-25T08:59:00Z", want: false},
+		{name: "at trigger", at: "2026-07-25T09:00:00Z", want: true},
+		{name: "inside window", at: "2026-07-25T09:30:00Z", want: true},
+		{name: "at window end", at: "2026-07-25T10:00:00Z", want: false},
+		{name: "after window", at: "2026-07-25T10:01:00Z", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.containsCron(mustParseRFC3339(t, tt.at))
+			if err != nil {
+				t.Fatalf("containsCron: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("containsCron(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleSpecPreviousCronTrigger(t *testing.T) {
+	spec := ScheduleSpec{Cron: "0 9 * * *", Duration: &metav1.Duration{Duration: time.Hour}}
+
+	prev, err := spec.previousCronTrigger(mustParseRFC3339(t, "2026-07-25T09:30:00Z"))
+	if err != nil {
+		t.Fatalf("previousCronTrigger: %v", err)
+	}
+
+	want := mustParseRFC3339(t, "2026-07-25T09:00:00Z")
+	if !prev.Equal(want) {
+		t.Errorf("previousCronTrigger = %v, want %v", prev, want)
+	}
+}
+
+func TestScheduleSpecNextCronTransition(t *testing.T) {
+	spec := ScheduleSpec{Cron: "0 9 * * *", Duration: &metav1.Duration{Duration: time.Hour}}
+
+	tests := []struct {
+		name string
+		at   string
+		want string
+	}{
+		{name: "before window closes to window end", at: "2026-07-25T09:30:00Z", want: "2026-07-25T10:00:00Z"},
+		{name: "after window to next trigger", at: "2026-07-25T10:00:00Z", want: "2026-07-26T09:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := spec.nextCronTransition(mustParseRFC3339(t, tt.at))
+			if err != nil {
+				t.Fatalf("nextCronTransition: %v", err)
+			}
+
+			want := mustParseRFC3339(t, tt.want)
+			if !next.Equal(want) {
+				t.Errorf("nextCronTransition(%s) = %v, want %v", tt.at, next, want)
+			}
+		})
+	}
+}
+
+func TestScheduleSpecValidateCronGranularity(t *testing.T) {
+	tests := []struct {
+		name    string
+		cron    string
+		wantErr bool
+	}{
+		{name: "daily is fine", cron: "0 9 * * *", wantErr: false},
+		{name: "every minute is fine", cron: "* * * * *", wantErr: false},
+		{name: "every second is rejected", cron: "* * * * * *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ScheduleSpec{Cron: tt.cron}
+
+			err := spec.validateCronGranularity()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCronGranularity(%q) error = %v, wantErr %v", tt.cron, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduleSpec defines the desired state of Schedule.
+type ScheduleSpec struct {
+	// StartDayOfWeek is the day of week, in the range 0 (Sunday) to 6 (Saturday), the window opens on.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=6
+	StartDayOfWeek int32 `json:"startDayOfWeek"`
+
+	// StartTime is the time of day, in "15:04" format, the window opens at.
+	StartTime string `json:"startTime"`
+
+	// EndTime is the time of day, in "15:04" format, the window closes at.
+	// A time not after StartTime is treated as rolling over to the following day.
+	EndTime string `json:"endTime"`
+
+	// Cron is a standard 5- or 6-field (with seconds) cron expression describing when the window
+	// opens, e.g. "0 9 * * 1-5" or "@weekly". When set, StartDayOfWeek, StartTime and EndTime are
+	// ignored and the window instead opens on every cron trigger and stays open for Duration.
+	// +optional
+	Cron string `json:"cron,omitempty"`
+
+	// TimeZone is the IANA time zone name (e.g. "Asia/Tokyo") that StartTime/EndTime and Cron are
+	// evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Duration is how long the window stays open after a Cron trigger fires. Required when Cron is set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Priority determines which Schedule wins when more than one is active at the same time.
+	// Higher values win; ties are broken by the shortest window, then by Schedule name.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// MinReplicas overrides the target HorizontalPodAutoscaler's minReplicas while this Schedule is active.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas overrides the target HorizontalPodAutoscaler's maxReplicas while this Schedule is active.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// Metrics overrides the target HorizontalPodAutoscaler's metrics while this Schedule is active.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// ScheduleStatus defines the observed state of Schedule.
+type ScheduleStatus struct {
+	// Conditions represent the latest available observations of the Schedule's state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Schedule is the Schema for the schedules API.
+type Schedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScheduleSpec   `json:"spec,omitempty"`
+	Status ScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScheduleList contains a list of Schedule.
+type ScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Schedule `json:"items"`
+}
+
+// Contains reports whether t falls within the window described by the ScheduleSpec.
+func (s ScheduleSpec) Contains(t time.Time) (bool, error) {
+	loc, err := s.location()
+	if err != nil {
+		return false, err
+	}
+
+	t = t.In(loc)
+
+	if s.Cron != "" {
+		return s.containsCron(t)
+	}
+
+	// A window opening on StartDayOfWeek can still be open at t: either t's own day is
+	// StartDayOfWeek, or t falls in the rollover tail of a window that opened the day before.
+	for _, dayOffset := range [2]int{0, -1} {
+		day := t.AddDate(0, 0, dayOffset)
+		if day.Weekday() != time.Weekday(s.StartDayOfWeek) {
+			continue
+		}
+
+		start, end, err := s.weeklyWindow(day)
+		if err != nil {
+			return false, err
+		}
+
+		if !t.Before(start) && t.Before(end) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// weeklyWindow returns the start and end instants of the window that opens on day (day's
+// Year/Month/Day combined with StartTime/EndTime), rolling end over to the following day when
+// EndTime is not after StartTime.
+func (s ScheduleSpec) weeklyWindow(day time.Time) (time.Time, time.Time, error) {
+	startTime, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	endTime, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), startTime.Hour(), startTime.Minute(), 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), endTime.Hour(), endTime.Minute(), 0, 0, day.Location())
+
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start, end, nil
+}
+
+// location returns the time.Location TimeZone refers to, defaulting to UTC when unset.
+func (s ScheduleSpec) location() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(s.TimeZone)
+}
+
+// Validate checks the fields of s for internal consistency, independent of any sibling Schedule.
+// It is used by the validating webhook to reject malformed Schedules before they are persisted.
+func (s ScheduleSpec) Validate() error {
+	if _, err := s.location(); err != nil {
+		return fmt.Errorf("timeZone: %w", err)
+	}
+
+	if s.Cron != "" {
+		if _, err := cronParser.Parse(s.Cron); err != nil {
+			return fmt.Errorf("cron: %w", err)
+		}
+
+		if err := s.validateCronGranularity(); err != nil {
+			return fmt.Errorf("cron: %w", err)
+		}
+
+		if s.Duration == nil || s.Duration.Duration <= 0 {
+			return fmt.Errorf("duration: must be set to a positive value when cron is set")
+		}
+	} else {
+		if s.StartDayOfWeek < 0 || s.StartDayOfWeek > 6 {
+			return fmt.Errorf("startDayOfWeek: must be between 0 and 6, got %d", s.StartDayOfWeek)
+		}
+
+		if _, err := time.Parse("15:04", s.StartTime); err != nil {
+			return fmt.Errorf("startTime: %w", err)
+		}
+
+		if _, err := time.Parse("15:04", s.EndTime); err != nil {
+			return fmt.Errorf("endTime: %w", err)
+		}
+	}
+
+	if s.MinReplicas != nil && s.MaxReplicas != nil && *s.MinReplicas > *s.MaxReplicas {
+		return fmt.Errorf("minReplicas: must not be greater than maxReplicas (%d > %d)", *s.MinReplicas, *s.MaxReplicas)
+	}
+
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&Schedule{}, &ScheduleList{})
+}
@@ -0,0 +1,79 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "testing"
+
+func TestScheduleSpecContainsWeeklyRollover(t *testing.T) {
+	// Saturday (6) 23:00 -> 02:00, rolling over into Sunday.
+	spec := ScheduleSpec{StartDayOfWeek: 6, StartTime: "23:00", EndTime: "02:00"}
+
+	tests := []struct {
+		name string
+		at   string
+		want bool
+	}{
+		{name: "before window opens Saturday", at: "2026-07-25T22:59:00Z", want: false},
+		{name: "at window open Saturday", at: "2026-07-25T23:00:00Z", want: true},
+		{name: "rollover tail just after midnight Sunday", at: "2026-07-26T00:30:00Z", want: true},
+		{name: "at window close Sunday", at: "2026-07-26T02:00:00Z", want: false},
+		{name: "after window closes Sunday", at: "2026-07-26T02:01:00Z", want: false},
+		{name: "unrelated time later Sunday", at: "2026-07-26T12:00:00Z", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.Contains(mustParseRFC3339(t, tt.at))
+			if err != nil {
+				t.Fatalf("Contains: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleSpecContainsWeeklyNoRollover(t *testing.T) {
+	// Monday (1) 09:00 -> 17:00, same-day window.
+	spec := ScheduleSpec{StartDayOfWeek: 1, StartTime: "09:00", EndTime: "17:00"}
+
+	tests := []struct {
+		name string
+		at   string
+		want bool
+	}{
+		{name: "before window Monday", at: "2026-07-27T08:59:00Z", want: false},
+		{name: "inside window Monday", at: "2026-07-27T12:00:00Z", want: true},
+		{name: "after window Monday", at: "2026-07-27T17:01:00Z", want: false},
+		{name: "previous day tail does not leak into Sunday", at: "2026-07-26T01:00:00Z", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.Contains(mustParseRFC3339(t, tt.at))
+			if err != nil {
+				t.Fatalf("Contains: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
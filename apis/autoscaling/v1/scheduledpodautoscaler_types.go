@@ -0,0 +1,117 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduledPodAutoscalerSpec defines the desired state of ScheduledPodAutoscaler.
+type ScheduledPodAutoscalerSpec struct {
+	// HorizontalPodAutoscalerSpec is the baseline spec applied to the managed HorizontalPodAutoscaler
+	// outside of any active Schedule window. It is always expressed in the autoscaling/v2 shape;
+	// on clusters that do not yet serve autoscaling/v2 the controller downconverts it to v2beta2
+	// at the API boundary, see ConvertHorizontalPodAutoscalerSpecToV2Beta2. Ignored when
+	// ScaleTargetRef is set.
+	// +optional
+	HorizontalPodAutoscalerSpec autoscalingv2.HorizontalPodAutoscalerSpec `json:"horizontalPodAutoscalerSpec,omitempty"`
+
+	// ScaleTargetRef switches this ScheduledPodAutoscaler into scale-target mode: instead of
+	// managing a HorizontalPodAutoscaler, the controller sets replicas directly on the referenced
+	// resource's scale subresource (Deployment, StatefulSet, ReplicaSet, or any custom resource
+	// that implements scale) using the winning active Schedule's MinReplicas, including 0 for
+	// scale-to-zero. The replica count in effect before the first Schedule activates is restored,
+	// via Status.PreviousReplicas, once no Schedule is active.
+	// +optional
+	ScaleTargetRef *autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef,omitempty"`
+}
+
+// ScheduledPodAutoscalerStatus defines the observed state of ScheduledPodAutoscaler.
+type ScheduledPodAutoscalerStatus struct {
+	// Phase is a high level summary of where the ScheduledPodAutoscaler is in its lifecycle.
+	// +optional
+	Phase ScheduledPodAutoscalerStatusPhase `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the ScheduledPodAutoscaler's state.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// ActiveSchedule is the child Schedule currently winning conflict resolution, or nil when no
+	// Schedule is active and the baseline HorizontalPodAutoscalerSpec applies.
+	// +optional
+	ActiveSchedule *ActiveScheduleStatus `json:"activeSchedule,omitempty"`
+
+	// PreviousReplicas is, in ScaleTargetRef mode, the replica count observed on the target
+	// immediately before a Schedule first took it over. It is restored once no Schedule is active
+	// and then cleared.
+	// +optional
+	PreviousReplicas *int32 `json:"previousReplicas,omitempty"`
+}
+
+// Validate checks the fields of s for internal consistency. It is used by the validating webhook
+// to reject malformed ScheduledPodAutoscalers before they are persisted.
+func (s ScheduledPodAutoscalerSpec) Validate() error {
+	if s.ScaleTargetRef != nil && (s.ScaleTargetRef.Kind == "" || s.ScaleTargetRef.Name == "") {
+		return fmt.Errorf("scaleTargetRef: kind and name must both be set")
+	}
+
+	min := s.HorizontalPodAutoscalerSpec.MinReplicas
+	max := s.HorizontalPodAutoscalerSpec.MaxReplicas
+
+	if min != nil && *min > max {
+		return fmt.Errorf("horizontalPodAutoscalerSpec.minReplicas: must not be greater than maxReplicas (%d > %d)", *min, max)
+	}
+
+	return nil
+}
+
+// ActiveScheduleStatus identifies the Schedule currently applied to the managed HorizontalPodAutoscaler.
+type ActiveScheduleStatus struct {
+	// Name is the name of the active Schedule.
+	Name string `json:"name"`
+
+	// Priority is the active Schedule's Priority at the time it was selected.
+	Priority int32 `json:"priority"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ScheduledPodAutoscaler is the Schema for the scheduledpodautoscalers API.
+type ScheduledPodAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScheduledPodAutoscalerSpec   `json:"spec,omitempty"`
+	Status ScheduledPodAutoscalerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScheduledPodAutoscalerList contains a list of ScheduledPodAutoscaler.
+type ScheduledPodAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScheduledPodAutoscaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScheduledPodAutoscaler{}, &ScheduledPodAutoscalerList{})
+}
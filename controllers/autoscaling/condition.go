@@ -0,0 +1,58 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+)
+
+// findCondition returns the condition with the given type, or nil if it is not present.
+func findCondition(conditions []autoscalingv1.Condition, conditionType string) *autoscalingv1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// setCondition sets newCondition in conditions, replacing any existing condition of the same type.
+func setCondition(conditions *[]autoscalingv1.Condition, newCondition autoscalingv1.Condition) {
+	if conditions == nil {
+		return
+	}
+
+	newCondition.LastTransitionTime = metav1.Now()
+
+	existing := findCondition(*conditions, newCondition.Type)
+	if existing == nil {
+		*conditions = append(*conditions, newCondition)
+
+		return
+	}
+
+	if existing.Status != newCondition.Status {
+		existing.Status = newCondition.Status
+		existing.LastTransitionTime = newCondition.LastTransitionTime
+	}
+
+	existing.Reason = newCondition.Reason
+	existing.Message = newCondition.Message
+}
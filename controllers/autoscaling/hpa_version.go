@@ -0,0 +1,101 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+)
+
+// HorizontalPodAutoscalerV2 is the group/version served by Kubernetes 1.23+.
+const HorizontalPodAutoscalerV2 = "autoscaling/v2"
+
+// HorizontalPodAutoscalerV2Beta2 is the group/version served by Kubernetes clusters older than
+// 1.23 and removed entirely in 1.26. Kept around only so the controller keeps working against
+// those clusters during the migration window.
+const HorizontalPodAutoscalerV2Beta2 = "autoscaling/v2beta2"
+
+// DiscoverHorizontalPodAutoscalerAPIVersion asks the cluster's discovery API which
+// HorizontalPodAutoscaler API version is available, preferring autoscaling/v2 and falling back to
+// autoscaling/v2beta2 for older clusters.
+func DiscoverHorizontalPodAutoscalerAPIVersion(dc discovery.DiscoveryInterface) (string, error) {
+	if _, err := dc.ServerResourcesForGroupVersion(HorizontalPodAutoscalerV2); err == nil {
+		return HorizontalPodAutoscalerV2, nil
+	}
+
+	if _, err := dc.ServerResourcesForGroupVersion(HorizontalPodAutoscalerV2Beta2); err == nil {
+		return HorizontalPodAutoscalerV2Beta2, nil
+	}
+
+	return "", errNoHorizontalPodAutoscalerAPI
+}
+
+var errNoHorizontalPodAutoscalerAPI = hpaAPIError("cluster serves neither autoscaling/v2 nor autoscaling/v2beta2")
+
+type hpaAPIError string
+
+func (e hpaAPIError) Error() string { return string(e) }
+
+// hpaAdapter hides the autoscaling/v2 vs autoscaling/v2beta2 HorizontalPodAutoscaler type behind a
+// single canonical, always-v2 spec so the reconciler only has to deal with one shape and converts
+// at the API boundary.
+type hpaAdapter interface {
+	client.Object
+	Spec() autoscalingv2.HorizontalPodAutoscalerSpec
+	SetSpec(autoscalingv2.HorizontalPodAutoscalerSpec)
+}
+
+// newHPAAdapter returns an empty adapter for the given namespaced name, backed by the
+// HorizontalPodAutoscaler API version the reconciler discovered for the cluster.
+func (r *ScheduledPodAutoscalerReconciler) newHPAAdapter(name, namespace string) hpaAdapter {
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: namespace}
+
+	if r.HPAAPIVersion == HorizontalPodAutoscalerV2Beta2 {
+		return &hpaV2Beta2Adapter{HorizontalPodAutoscaler: &hpav2beta2.HorizontalPodAutoscaler{ObjectMeta: objectMeta}}
+	}
+
+	return &hpaV2Adapter{HorizontalPodAutoscaler: &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: objectMeta}}
+}
+
+type hpaV2Adapter struct {
+	*autoscalingv2.HorizontalPodAutoscaler
+}
+
+func (a *hpaV2Adapter) Spec() autoscalingv2.HorizontalPodAutoscalerSpec {
+	return a.HorizontalPodAutoscaler.Spec
+}
+
+func (a *hpaV2Adapter) SetSpec(spec autoscalingv2.HorizontalPodAutoscalerSpec) {
+	a.HorizontalPodAutoscaler.Spec = spec
+}
+
+type hpaV2Beta2Adapter struct {
+	*hpav2beta2.HorizontalPodAutoscaler
+}
+
+func (a *hpaV2Beta2Adapter) Spec() autoscalingv2.HorizontalPodAutoscalerSpec {
+	return autoscalingv1.ConvertHorizontalPodAutoscalerSpecFromV2Beta2(a.HorizontalPodAutoscaler.Spec)
+}
+
+func (a *hpaV2Beta2Adapter) SetSpec(spec autoscalingv2.HorizontalPodAutoscalerSpec) {
+	a.HorizontalPodAutoscaler.Spec = autoscalingv1.ConvertHorizontalPodAutoscalerSpecToV2Beta2(spec)
+}
@@ -0,0 +1,156 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// These tests exercise DiscoverHorizontalPodAutoscalerAPIVersion and the hpaAdapter
+// implementations against a real API server started by envtest. They only run when
+// KUBEBUILDER_ASSETS points at a kube-apiserver/etcd binary pair (set by `make test` or the
+// `setup-envtest` tool); CI covers multiple Kubernetes minor versions by re-running this package
+// once per KUBEBUILDER_ASSETS matrix entry, since autoscaling/v2beta2 is only served by clusters
+// older than 1.26.
+func newTestEnv(t *testing.T) *envtest.Environment {
+	t.Helper()
+
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS not set; skipping envtest-backed test")
+	}
+
+	return &envtest.Environment{}
+}
+
+func TestDiscoverHorizontalPodAutoscalerAPIVersionEnvtest(t *testing.T) {
+	testEnv := newTestEnv(t)
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("start envtest environment: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := testEnv.Stop(); err != nil {
+			t.Errorf("stop envtest environment: %v", err)
+		}
+	})
+
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		t.Fatalf("new discovery client: %v", err)
+	}
+
+	version, err := DiscoverHorizontalPodAutoscalerAPIVersion(dc)
+	if err != nil {
+		t.Fatalf("DiscoverHorizontalPodAutoscalerAPIVersion: %v", err)
+	}
+
+	if version != HorizontalPodAutoscalerV2 && version != HorizontalPodAutoscalerV2Beta2 {
+		t.Errorf("DiscoverHorizontalPodAutoscalerAPIVersion() = %q, want %q or %q", version, HorizontalPodAutoscalerV2, HorizontalPodAutoscalerV2Beta2)
+	}
+}
+
+func TestHPAAdapterRoundTripEnvtest(t *testing.T) {
+	testEnv := newTestEnv(t)
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("start envtest environment: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := testEnv.Stop(); err != nil {
+			t.Errorf("stop envtest environment: %v", err)
+		}
+	})
+
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		t.Fatalf("new discovery client: %v", err)
+	}
+
+	served, err := DiscoverHorizontalPodAutoscalerAPIVersion(dc)
+	if err != nil {
+		t.Fatalf("DiscoverHorizontalPodAutoscalerAPIVersion: %v", err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "hpa-adapter-test-"}}
+	if err := k8sClient.Create(ctx, ns); err != nil {
+		t.Fatalf("create namespace: %v", err)
+	}
+
+	reconciler := &ScheduledPodAutoscalerReconciler{Client: k8sClient, Scheme: scheme.Scheme}
+
+	spec := autoscalingv2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web", APIVersion: "apps/v1"},
+		MinReplicas:    int32Ptr(1),
+		MaxReplicas:    10,
+	}
+
+	for _, apiVersion := range []string{HorizontalPodAutoscalerV2, HorizontalPodAutoscalerV2Beta2} {
+		t.Run(apiVersion, func(t *testing.T) {
+			reconciler.HPAAPIVersion = apiVersion
+
+			adapter := reconciler.newHPAAdapter("web", ns.Name)
+			adapter.SetSpec(spec)
+
+			// autoscaling/v2beta2 was removed in Kubernetes 1.26+, so this envtest binary may not
+			// serve it. Only persist to the server when it's the version envtest actually serves;
+			// otherwise still assert the adapter's in-memory conversion round trip below.
+			if apiVersion == served {
+				switch typed := adapter.(type) {
+				case *hpaV2Adapter:
+					if err := k8sClient.Create(ctx, typed.HorizontalPodAutoscaler); err != nil {
+						t.Fatalf("create HPA: %v", err)
+					}
+				case *hpaV2Beta2Adapter:
+					if err := k8sClient.Create(ctx, typed.HorizontalPodAutoscaler); err != nil {
+						t.Fatalf("create HPA: %v", err)
+					}
+				}
+			} else {
+				t.Logf("envtest API server serves %s, not %s; asserting the conversion round trip only", served, apiVersion)
+			}
+
+			if got := adapter.Spec(); got.MaxReplicas != spec.MaxReplicas {
+				t.Errorf("adapter.Spec().MaxReplicas = %d, want %d", got.MaxReplicas, spec.MaxReplicas)
+			}
+		})
+	}
+}
+
+func int32Ptr(v int32) *int32 { return &v }
@@ -0,0 +1,71 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	managedScheduledPodAutoscalers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "scheduled_pod_autoscaler_managed_total",
+		Help: "Number of ScheduledPodAutoscalers currently managed by this controller.",
+	})
+
+	activeSchedule = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scheduled_pod_autoscaler_active_schedule",
+		Help: "Set to 1 for the Schedule currently active on a ScheduledPodAutoscaler.",
+	}, []string{"namespace", "spa", "schedule"})
+
+	reconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduled_pod_autoscaler_reconcile_errors_total",
+		Help: "Total number of reconcile errors per ScheduledPodAutoscaler.",
+	}, []string{"namespace", "spa"})
+
+	targetUpdatesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduled_pod_autoscaler_target_updates_total",
+		Help: "Total number of times the managed HorizontalPodAutoscaler or scale target was updated.",
+	}, []string{"namespace", "spa"})
+
+	nextTransitionSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scheduled_pod_autoscaler_next_transition_seconds",
+		Help: "Seconds until a ScheduledPodAutoscaler is next reconciled: the time to its earliest Schedule transition, capped at one hour, or 30 seconds when no transition is known.",
+	}, []string{"namespace", "spa"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		managedScheduledPodAutoscalers,
+		activeSchedule,
+		reconcileErrorsTotal,
+		targetUpdatesTotal,
+		nextTransitionSeconds,
+	)
+}
+
+// setActiveScheduleMetric updates the active-schedule gauge, clearing the previous Schedule's
+// series when the active Schedule changed.
+func setActiveScheduleMetric(namespace, spa string, previous, active *string) {
+	if previous != nil && (active == nil || *previous != *active) {
+		activeSchedule.DeleteLabelValues(namespace, spa, *previous)
+	}
+
+	if active != nil {
+		activeSchedule.WithLabelValues(namespace, spa, *active).Set(1)
+	}
+}
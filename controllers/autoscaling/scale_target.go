@@ -0,0 +1,157 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+)
+
+// reconcileScaleTarget implements ScaleTargetRef mode: instead of managing a
+// HorizontalPodAutoscaler it sets replicas directly on the target's scale subresource, restoring
+// the replica count observed before the first Schedule took over once none is active anymore.
+func (r *ScheduledPodAutoscalerReconciler) reconcileScaleTarget(ctx context.Context, log logr.Logger,
+	spa *autoscalingv1.ScheduledPodAutoscaler) (time.Duration, error) {
+	ref := *spa.Spec.ScaleTargetRef
+
+	gr, err := r.scaleTargetGroupResource(ref)
+	if err != nil {
+		log.Error(err, "unable to resolve scaleTargetRef", "scaleTargetRef", ref)
+
+		return defaultRequeueInterval, err
+	}
+
+	current, err := r.ScaleClient.Scales(spa.Namespace).Get(ctx, gr, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Error(err, "unable to fetch scale subresource", "scaleTargetRef", ref)
+
+		return defaultRequeueInterval, err
+	}
+
+	var schedules autoscalingv1.ScheduleList
+	if err := r.List(ctx, &schedules, client.MatchingFields(map[string]string{ownerControllerField: spa.Name})); err != nil {
+		log.Error(err, "unable to list child Schedules")
+
+		return defaultRequeueInterval, err
+	}
+
+	now := time.Now()
+
+	var requeueAfter time.Duration
+
+	var matches []autoscalingv1.Schedule
+
+	for _, schedule := range schedules.Items {
+		isContains, err := schedule.Spec.Contains(now)
+		if err != nil {
+			log.Error(err, "unable to check contains Schedule", "schedule", schedule.Name)
+
+			return boundRequeueAfter(requeueAfter), err
+		}
+
+		if isContains {
+			matches = append(matches, schedule)
+		}
+
+		transition, err := schedule.Spec.NextTransition(now)
+		if err != nil {
+			log.Error(err, "unable to compute next transition", "schedule", schedule.Name)
+
+			continue
+		}
+
+		requeueAfter = earliestTransition(requeueAfter, now, transition)
+	}
+
+	var active *autoscalingv1.ActiveScheduleStatus
+
+	desired := current.Spec.Replicas
+
+	if len(matches) > 0 {
+		winner, err := highestPrioritySchedule(matches)
+		if err != nil {
+			log.Error(err, "unable to resolve conflicting Schedules")
+
+			return boundRequeueAfter(requeueAfter), err
+		}
+
+		if winner.Spec.MinReplicas != nil {
+			desired = *winner.Spec.MinReplicas
+		}
+
+		active = &autoscalingv1.ActiveScheduleStatus{Name: winner.Name, Priority: winner.Spec.Priority}
+	}
+
+	switch {
+	case active != nil:
+		if spa.Status.PreviousReplicas == nil {
+			previous := current.Spec.Replicas
+			spa.Status.PreviousReplicas = &previous
+		}
+	case spa.Status.PreviousReplicas != nil:
+		desired = *spa.Status.PreviousReplicas
+		spa.Status.PreviousReplicas = nil
+	}
+
+	// Persist PreviousReplicas (and ActiveSchedule) before mutating the scale subresource: if the
+	// process dies between the two, the next reconcile must still know the replica count to
+	// restore rather than re-deriving it from the already-scaled target.
+	if err := r.recordActiveSchedule(ctx, log, spa, active); err != nil {
+		log.Error(err, "unable to record active schedule")
+
+		return boundRequeueAfter(requeueAfter), err
+	}
+
+	if current.Spec.Replicas != desired {
+		current.Spec.Replicas = desired
+
+		if _, err := r.ScaleClient.Scales(spa.Namespace).Update(ctx, gr, current, metav1.UpdateOptions{}); err != nil {
+			log.Error(err, "unable to update scale subresource", "scaleTargetRef", ref)
+
+			return boundRequeueAfter(requeueAfter), err
+		}
+
+		targetUpdatesTotal.WithLabelValues(spa.Namespace, spa.Name).Inc()
+		log.Info("successfully updated scale subresource", "scaleTargetRef", ref, "replicas", current.Spec.Replicas)
+	}
+
+	return boundRequeueAfter(requeueAfter), nil
+}
+
+// scaleTargetGroupResource maps a CrossVersionObjectReference to the GroupResource the scale
+// client needs, via the manager's RESTMapper.
+func (r *ScheduledPodAutoscalerReconciler) scaleTargetGroupResource(ref autoscalingv2.CrossVersionObjectReference) (schema.GroupResource, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return schema.GroupResource{}, err
+	}
+
+	mapping, err := r.RESTMapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return schema.GroupResource{}, err
+	}
+
+	return mapping.Resource.GroupResource(), nil
+}
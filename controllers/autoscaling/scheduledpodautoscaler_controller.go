@@ -23,10 +23,16 @@ import (
 
 	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
 	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	hpav2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -34,18 +40,41 @@ import (
 // ScheduledPodAutoscalerReconciler reconciles a ScheduledPodAutoscaler object.
 type ScheduledPodAutoscalerReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// RESTMapper and ScaleClient are used in ScaleTargetRef mode to resolve and scale arbitrary
+	// resources that implement the scale subresource.
+	RESTMapper  meta.RESTMapper
+	ScaleClient scale.ScalesGetter
+
+	// HPAAPIVersion is the HorizontalPodAutoscaler API version discovered for the cluster, one of
+	// HorizontalPodAutoscalerV2 or HorizontalPodAutoscalerV2Beta2. It is populated by
+	// SetupWithManager and defaults to HorizontalPodAutoscalerV2 when left unset.
+	HPAAPIVersion string
 }
 
 // +kubebuilder:rbac:groups=autoscaling.d-kuro.github.io,resources=scheduledpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling.d-kuro.github.io,resources=scheduledpodautoscalers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 
-func (r *ScheduledPodAutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
+func (r *ScheduledPodAutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := r.Log.WithValues("scheduledpodautoscaler", req.NamespacedName)
 
+	defer func() {
+		if err != nil {
+			reconcileErrorsTotal.WithLabelValues(req.Namespace, req.Name).Inc()
+		} else {
+			nextTransitionSeconds.WithLabelValues(req.Namespace, req.Name).Set(result.RequeueAfter.Seconds())
+		}
+	}()
+
+	var spaList autoscalingv1.ScheduledPodAutoscalerList
+	if listErr := r.List(ctx, &spaList); listErr == nil {
+		managedScheduledPodAutoscalers.Set(float64(len(spaList.Items)))
+	}
+
 	var spa autoscalingv1.ScheduledPodAutoscaler
 	if err := r.Get(ctx, req.NamespacedName, &spa); err != nil {
 		log.Error(err, "unable to fetch ScheduledPodAutoscaler")
@@ -53,25 +82,29 @@ func (r *ScheduledPodAutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Res
 		return ctrl.Result{}, err
 	}
 
-	var hpa hpav2beta2.HorizontalPodAutoscaler
-	if err := r.Get(ctx, req.NamespacedName, &hpa); apierrors.IsNotFound(err) {
+	if spa.Spec.ScaleTargetRef != nil {
+		requeueAfter, err := r.reconcileScaleTarget(ctx, log, &spa)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}, nil
+	}
+
+	hpa := r.newHPAAdapter(req.Name, req.Namespace)
+	if err := r.Get(ctx, req.NamespacedName, hpa); apierrors.IsNotFound(err) {
 		log.Info("unable to fetch hpa, try to create one", "namespacedName", req.NamespacedName)
 
-		hpa = hpav2beta2.HorizontalPodAutoscaler{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      req.Name,
-				Namespace: req.Namespace,
-			},
-			Spec: spa.Spec.HorizontalPodAutoscalerSpec,
-		}
+		hpa = r.newHPAAdapter(req.Name, req.Namespace)
+		hpa.SetSpec(spa.Spec.HorizontalPodAutoscalerSpec)
 
-		if err := ctrl.SetControllerReference(&spa, &hpa, r.Scheme); err != nil {
+		if err := ctrl.SetControllerReference(&spa, hpa, r.Scheme); err != nil {
 			log.Error(err, "unable to set ownerReference", "hpa", hpa)
 
 			return ctrl.Result{}, err
 		}
 
-		if err := r.Create(ctx, &hpa, &client.CreateOptions{}); err != nil {
+		if err := r.Create(ctx, hpa, &client.CreateOptions{}); err != nil {
 			log.Info("unable to HPA", "hpa", hpa)
 
 			return ctrl.Result{}, err
@@ -84,7 +117,7 @@ func (r *ScheduledPodAutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Res
 		return ctrl.Result{}, err
 	}
 
-	updated, err := r.reconcileSchedule(ctx, log, spa, hpa)
+	updated, active, requeueAfter, err := r.reconcileSchedule(ctx, log, spa, hpa)
 	if err != nil {
 		log.Error(err, "unable to reconcile")
 
@@ -92,90 +125,219 @@ func (r *ScheduledPodAutoscalerReconciler) Reconcile(req ctrl.Request) (ctrl.Res
 	}
 
 	if !updated {
-		hpa.Spec = spa.Spec.HorizontalPodAutoscalerSpec
-		if err := r.Update(ctx, &hpa, &client.UpdateOptions{}); err != nil {
+		hpa.SetSpec(spa.Spec.HorizontalPodAutoscalerSpec)
+		if err := r.Update(ctx, hpa, &client.UpdateOptions{}); err != nil {
 			log.Error(err, "unable to update HPA", "hpa", hpa)
 
 			return ctrl.Result{}, err
 		}
 
+		targetUpdatesTotal.WithLabelValues(spa.Namespace, spa.Name).Inc()
 		log.Info("successfully update HPA", "hpa", hpa)
 	}
 
-	return ctrl.Result{Requeue: true, RequeueAfter: 30 * time.Second}, nil
+	if err := r.recordActiveSchedule(ctx, log, &spa, active); err != nil {
+		log.Error(err, "unable to record active schedule")
+
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}, nil
+}
+
+// defaultRequeueInterval is used when no child Schedule has a computable next transition, e.g.
+// because the SPA has no Schedules at all.
+const defaultRequeueInterval = 30 * time.Second
+
+// maxRequeueInterval bounds how long reconcileSchedule and reconcileScaleTarget will ever wait
+// between reconciles, even when every child Schedule's next transition is far in the future. It
+// is a safety net against clock skew or a miscomputed transition, not the steady-state interval.
+const maxRequeueInterval = time.Hour
+
+// earliestTransition folds transition into the running minimum requeueAfter seen so far. A zero
+// requeueAfter means "no future transition observed yet".
+func earliestTransition(requeueAfter time.Duration, now, transition time.Time) time.Duration {
+	if transition.IsZero() {
+		return requeueAfter
+	}
+
+	if d := transition.Sub(now); d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+		return d
+	}
+
+	return requeueAfter
 }
 
+// boundRequeueAfter applies defaultRequeueInterval when no child Schedule produced a future
+// transition, and caps the result at maxRequeueInterval.
+func boundRequeueAfter(requeueAfter time.Duration) time.Duration {
+	switch {
+	case requeueAfter <= 0:
+		return defaultRequeueInterval
+	case requeueAfter > maxRequeueInterval:
+		return maxRequeueInterval
+	default:
+		return requeueAfter
+	}
+}
+
+// reconcileSchedule evaluates every child Schedule, applies the winner (highest Priority, ties
+// broken by the shortest window then by name) to hpa, and reports how soon the caller should
+// requeue to catch the next window transition.
 func (r *ScheduledPodAutoscalerReconciler) reconcileSchedule(ctx context.Context, log logr.Logger,
-	spa autoscalingv1.ScheduledPodAutoscaler, hpa hpav2beta2.HorizontalPodAutoscaler) (bool, error) {
+	spa autoscalingv1.ScheduledPodAutoscaler, hpa hpaAdapter) (bool, *autoscalingv1.ActiveScheduleStatus, time.Duration, error) {
 	var schedules autoscalingv1.ScheduleList
 	if err := r.List(ctx, &schedules, client.MatchingFields(map[string]string{ownerControllerField: spa.Name})); err != nil {
 		log.Error(err, "unable to list child Schedules")
 
-		return false, err
+		return false, nil, defaultRequeueInterval, err
 	}
 
-	// Sort by start day of week.
-	// If the start day of week are the same. sort by start time.
-	sort.SliceStable(schedules.Items, func(i, j int) bool {
-		if schedules.Items[i].Spec.StartDayOfWeek == schedules.Items[j].Spec.StartDayOfWeek {
-			startTime1, err := time.Parse("15:04", schedules.Items[i].Spec.StartTime)
-			if err != nil {
-				log.Error(err, "unable to parse start time", "schedule", schedules.Items[i])
+	now := time.Now()
 
-				return false
-			}
+	var requeueAfter time.Duration
 
-			startTime2, err := time.Parse("15:04", schedules.Items[j].Spec.StartTime)
-			if err != nil {
-				log.Error(err, "unable to parse start time", "schedule", schedules.Items[j])
+	var matches []autoscalingv1.Schedule
 
-				return false
-			}
+	for _, schedule := range schedules.Items {
+		isContains, err := schedule.Spec.Contains(now)
+		if err != nil {
+			log.Error(err, "unable to check contains Schedule", "schedule", schedule.Name)
 
-			return startTime1.Unix() < startTime2.Unix()
+			return false, nil, boundRequeueAfter(requeueAfter), err
 		}
 
-		return schedules.Items[i].Spec.StartDayOfWeek < schedules.Items[j].Spec.StartDayOfWeek
-	})
-
-	now := time.Now()
-	updated := false
+		if isContains {
+			matches = append(matches, schedule)
+		}
 
-	for _, schedule := range schedules.Items {
-		isContains, err := schedule.Spec.Contains(now)
+		transition, err := schedule.Spec.NextTransition(now)
 		if err != nil {
-			log.Error(err, "unable to check contains Schedule")
+			log.Error(err, "unable to compute next transition", "schedule", schedule.Name)
 
-			return updated, err
+			continue
 		}
 
-		if isContains {
-			if schedule.Spec.MaxReplicas != nil {
-				hpa.Spec.MaxReplicas = *schedule.Spec.MaxReplicas
-			}
+		requeueAfter = earliestTransition(requeueAfter, now, transition)
+	}
+
+	if len(matches) == 0 {
+		return false, nil, boundRequeueAfter(requeueAfter), nil
+	}
+
+	winner, err := highestPrioritySchedule(matches)
+	if err != nil {
+		log.Error(err, "unable to resolve conflicting Schedules")
+
+		return false, nil, boundRequeueAfter(requeueAfter), err
+	}
+
+	spec := spa.Spec.HorizontalPodAutoscalerSpec
+
+	if winner.Spec.MaxReplicas != nil {
+		spec.MaxReplicas = *winner.Spec.MaxReplicas
+	}
 
-			if schedule.Spec.MinReplicas != nil {
-				hpa.Spec.MinReplicas = schedule.Spec.MinReplicas
-			}
+	if winner.Spec.MinReplicas != nil {
+		spec.MinReplicas = winner.Spec.MinReplicas
+	}
 
-			if schedule.Spec.Metrics != nil {
-				hpa.Spec.Metrics = schedule.Spec.Metrics
-			}
+	if winner.Spec.Metrics != nil {
+		spec.Metrics = winner.Spec.Metrics
+	}
 
-			if err := r.Update(ctx, &hpa, &client.UpdateOptions{}); err != nil {
-				log.Error(err, "unable to update HPA", "hpa", hpa)
+	hpa.SetSpec(spec)
 
-				return updated, err
-			}
+	if err := r.Update(ctx, hpa, &client.UpdateOptions{}); err != nil {
+		log.Error(err, "unable to update HPA", "hpa", hpa)
 
-			updated = true
-			log.Info("successfully update HPA", "hpa", hpa)
+		return false, nil, boundRequeueAfter(requeueAfter), err
+	}
 
-			return updated, nil
+	targetUpdatesTotal.WithLabelValues(spa.Namespace, spa.Name).Inc()
+	log.Info("successfully update HPA", "hpa", hpa, "activeSchedule", winner.Name)
+
+	active := &autoscalingv1.ActiveScheduleStatus{Name: winner.Name, Priority: winner.Spec.Priority}
+
+	return true, active, boundRequeueAfter(requeueAfter), nil
+}
+
+// highestPrioritySchedule picks the winner among overlapping Schedules: highest Priority wins,
+// ties are broken by the shortest window, then by name.
+func highestPrioritySchedule(schedules []autoscalingv1.Schedule) (autoscalingv1.Schedule, error) {
+	type candidate struct {
+		schedule autoscalingv1.Schedule
+		window   time.Duration
+	}
+
+	candidates := make([]candidate, 0, len(schedules))
+
+	for _, schedule := range schedules {
+		window, err := schedule.Spec.WindowDuration()
+		if err != nil {
+			return autoscalingv1.Schedule{}, err
 		}
+
+		candidates = append(candidates, candidate{schedule: schedule, window: window})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].schedule.Spec.Priority != candidates[j].schedule.Spec.Priority {
+			return candidates[i].schedule.Spec.Priority > candidates[j].schedule.Spec.Priority
+		}
+
+		if candidates[i].window != candidates[j].window {
+			return candidates[i].window < candidates[j].window
+		}
+
+		return candidates[i].schedule.Name < candidates[j].schedule.Name
+	})
+
+	return candidates[0].schedule, nil
+}
+
+// recordActiveSchedule persists spa.Status.ActiveSchedule when it changed and emits a Kubernetes
+// Event so operators can audit override behavior.
+func (r *ScheduledPodAutoscalerReconciler) recordActiveSchedule(ctx context.Context, log logr.Logger,
+	spa *autoscalingv1.ScheduledPodAutoscaler, active *autoscalingv1.ActiveScheduleStatus) error {
+	current := spa.Status.ActiveSchedule
+	if current == nil && active == nil {
+		return nil
+	}
+
+	if current != nil && active != nil && *current == *active {
+		return nil
+	}
+
+	spa.Status.ActiveSchedule = active
+
+	if err := r.Status().Update(ctx, spa); err != nil {
+		return err
+	}
+
+	var previousName, activeName *string
+	if current != nil {
+		previousName = &current.Name
+	}
+
+	if active != nil {
+		activeName = &active.Name
+	}
+
+	setActiveScheduleMetric(spa.Namespace, spa.Name, previousName, activeName)
+
+	switch {
+	case active == nil:
+		r.Recorder.Eventf(spa, corev1.EventTypeNormal, "ScheduleDeactivated",
+			"no Schedule is active, baseline HorizontalPodAutoscalerSpec applies")
+	default:
+		r.Recorder.Eventf(spa, corev1.EventTypeNormal, "ActiveScheduleChanged",
+			"Schedule %q (priority %d) is now active", active.Name, active.Priority)
 	}
 
-	return updated, nil
+	log.Info("active schedule changed", "activeSchedule", active)
+
+	return nil
 }
 
 func setScheduledPodAutoscalerAvailableStatus(spa *autoscalingv1.ScheduledPodAutoscaler) bool {
@@ -220,7 +382,7 @@ func setScheduledPodAutoscalerUnavailableStatus(spa *autoscalingv1.ScheduledPodA
 
 const ownerControllerField = ".metadata.controller"
 
-func indexByOwnerScheduledPodAutoscaler(obj runtime.Object) []string {
+func indexByOwnerScheduledPodAutoscaler(obj client.Object) []string {
 	schedule := obj.(*autoscalingv1.Schedule)
 
 	owner := metav1.GetControllerOf(schedule)
@@ -244,9 +406,30 @@ func (r *ScheduledPodAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) er
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	if r.HPAAPIVersion == "" {
+		dc, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+		if err != nil {
+			return err
+		}
+
+		version, err := DiscoverHorizontalPodAutoscalerAPIVersion(dc)
+		if err != nil {
+			return err
+		}
+
+		r.HPAAPIVersion = version
+		r.Log.Info("discovered HorizontalPodAutoscaler API version", "version", version)
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&autoscalingv1.ScheduledPodAutoscaler{}).
-		Owns(&autoscalingv1.Schedule{}).
-		Owns(&hpav2beta2.HorizontalPodAutoscaler{}).
-		Complete(r)
+		Owns(&autoscalingv1.Schedule{})
+
+	if r.HPAAPIVersion == HorizontalPodAutoscalerV2Beta2 {
+		builder = builder.Owns(&hpav2beta2.HorizontalPodAutoscaler{})
+	} else {
+		builder = builder.Owns(&autoscalingv2.HorizontalPodAutoscaler{})
+	}
+
+	return builder.Complete(r)
 }
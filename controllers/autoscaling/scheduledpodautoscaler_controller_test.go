@@ -0,0 +1,128 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+)
+
+func schedule(name string, priority int32, startTime, endTime string) autoscalingv1.Schedule {
+	return autoscalingv1.Schedule{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: autoscalingv1.ScheduleSpec{
+			StartTime: startTime,
+			EndTime:   endTime,
+			Priority:  priority,
+		},
+	}
+}
+
+func TestHighestPrioritySchedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []autoscalingv1.Schedule
+		want      string
+	}{
+		{
+			name: "highest priority wins",
+			schedules: []autoscalingv1.Schedule{
+				schedule("low", 1, "09:00", "17:00"),
+				schedule("high", 5, "09:00", "17:00"),
+			},
+			want: "high",
+		},
+		{
+			name: "tie broken by shortest window",
+			schedules: []autoscalingv1.Schedule{
+				schedule("long", 1, "00:00", "23:00"),
+				schedule("short", 1, "09:00", "10:00"),
+			},
+			want: "short",
+		},
+		{
+			name: "tie broken by name when priority and window both match",
+			schedules: []autoscalingv1.Schedule{
+				schedule("bravo", 1, "09:00", "17:00"),
+				schedule("alpha", 1, "09:00", "17:00"),
+			},
+			want: "alpha",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := highestPrioritySchedule(tt.schedules)
+			if err != nil {
+				t.Fatalf("highestPrioritySchedule: %v", err)
+			}
+
+			if got.Name != tt.want {
+				t.Errorf("highestPrioritySchedule() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestEarliestTransition(t *testing.T) {
+	now := time.Date(2026, 7, 25, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		requeue    time.Duration
+		transition time.Time
+		want       time.Duration
+	}{
+		{name: "zero requeueAfter adopts the first transition", requeue: 0, transition: now.Add(2 * time.Hour), want: 2 * time.Hour},
+		{name: "closer transition wins", requeue: 2 * time.Hour, transition: now.Add(time.Hour), want: time.Hour},
+		{name: "farther transition is ignored", requeue: time.Hour, transition: now.Add(2 * time.Hour), want: time.Hour},
+		{name: "zero transition is ignored", requeue: time.Hour, transition: time.Time{}, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := earliestTransition(tt.requeue, now, tt.transition)
+			if got != tt.want {
+				t.Errorf("earliestTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundRequeueAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		requeue time.Duration
+		want    time.Duration
+	}{
+		{name: "zero falls back to the default", requeue: 0, want: defaultRequeueInterval},
+		{name: "within bounds is unchanged", requeue: time.Minute, want: time.Minute},
+		{name: "beyond the cap is clamped", requeue: 48 * time.Hour, want: maxRequeueInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boundRequeueAfter(tt.requeue); got != tt.want {
+				t.Errorf("boundRequeueAfter(%v) = %v, want %v", tt.requeue, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// conflictProbeWindow bounds how far into the future ScheduleValidator compares two Schedules'
+// actual active windows for overlap.
+const conflictProbeWindow = 7 * 24 * time.Hour
+
+// ScheduleDefaulter defaults Schedule.Spec.TimeZone to the controller's configured default when unset.
+type ScheduleDefaulter struct {
+	Decoder         *admission.Decoder
+	DefaultTimeZone string
+}
+
+func (d *ScheduleDefaulter) Handle(ctx context.Context, req admission.Request) admission.Response {
+	schedule := &autoscalingv1.Schedule{}
+	if err := d.Decoder.Decode(req, schedule); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if schedule.Spec.TimeZone != "" || d.DefaultTimeZone == "" {
+		return admission.Allowed("")
+	}
+
+	schedule.Spec.TimeZone = d.DefaultTimeZone
+
+	marshaled, err := json.Marshal(schedule)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// +kubebuilder:rbac:groups=autoscaling.d-kuro.github.io,resources=scheduledpodautoscalers,verbs=get
+// +kubebuilder:rbac:groups=autoscaling.d-kuro.github.io,resources=schedules,verbs=list
+
+// ScheduleValidator rejects Schedules with malformed fields, no existing owner
+// ScheduledPodAutoscaler, or a window that overlaps a sibling Schedule of equal Priority.
+type ScheduleValidator struct {
+	Client  client.Client
+	Decoder *admission.Decoder
+}
+
+func (v *ScheduleValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	schedule := &autoscalingv1.Schedule{}
+	if err := v.Decoder.Decode(req, schedule); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := schedule.Spec.Validate(); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	owner := metav1.GetControllerOf(schedule)
+	if owner == nil || owner.APIVersion != autoscalingv1.GroupVersion.String() || owner.Kind != "ScheduledPodAutoscaler" {
+		return admission.Denied("schedule must be owned by a ScheduledPodAutoscaler")
+	}
+
+	var spa autoscalingv1.ScheduledPodAutoscaler
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: schedule.Namespace, Name: owner.Name}, &spa); err != nil {
+		return admission.Denied(fmt.Sprintf("owner ScheduledPodAutoscaler %q not found: %v", owner.Name, err))
+	}
+
+	var siblings autoscalingv1.ScheduleList
+	if err := v.Client.List(ctx, &siblings, client.InNamespace(schedule.Namespace)); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	for _, sibling := range siblings.Items {
+		if sibling.Name == schedule.Name {
+			continue
+		}
+
+		siblingOwner := metav1.GetControllerOf(&sibling)
+		if siblingOwner == nil || siblingOwner.Name != owner.Name {
+			continue
+		}
+
+		if sibling.Spec.Priority != schedule.Spec.Priority {
+			continue
+		}
+
+		conflicts, err := windowsOverlap(schedule.Spec, sibling.Spec)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+
+		if conflicts {
+			return admission.Denied(fmt.Sprintf("window overlaps sibling Schedule %q at the same priority (%d)", sibling.Name, schedule.Spec.Priority))
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+// window is a half-open active interval [Start, End).
+type window struct {
+	Start, End time.Time
+}
+
+// activeWindows enumerates every interval during which s is active within [from, until), derived
+// from s's actual Contains/NextTransition boundaries rather than a fixed sampling grid. This
+// catches overlaps a coarse probe would miss, e.g. two short, grid-misaligned cron windows.
+func activeWindows(s autoscalingv1.ScheduleSpec, from, until time.Time) ([]window, error) {
+	active, err := s.Contains(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []window
+
+	t, start := from, from
+
+	for t.Before(until) {
+		next, err := s.NextTransition(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if next.IsZero() || !next.Before(until) {
+			break
+		}
+
+		nextActive, err := s.Contains(next)
+		if err != nil {
+			return nil, err
+		}
+
+		if active && !nextActive {
+			windows = append(windows, window{Start: start, End: next})
+		} else if !active && nextActive {
+			start = next
+		}
+
+		active = nextActive
+		t = next
+	}
+
+	if active {
+		windows = append(windows, window{Start: start, End: until})
+	}
+
+	return windows, nil
+}
+
+// windowsOverlap reports whether a and b are ever simultaneously active within conflictProbeWindow
+// of now.
+func windowsOverlap(a, b autoscalingv1.ScheduleSpec) (bool, error) {
+	now := time.Now()
+	until := now.Add(conflictProbeWindow)
+
+	aWindows, err := activeWindows(a, now, until)
+	if err != nil {
+		return false, err
+	}
+
+	bWindows, err := activeWindows(b, now, until)
+	if err != nil {
+		return false, err
+	}
+
+	return windowListsOverlap(aWindows, bWindows), nil
+}
+
+// windowListsOverlap reports whether any interval in a overlaps any interval in b. Both slices
+// must be sorted by Start, which activeWindows guarantees.
+func windowListsOverlap(a, b []window) bool {
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		if a[i].Start.Before(b[j].End) && b[j].Start.Before(a[i].End) {
+			return true
+		}
+
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return false
+}
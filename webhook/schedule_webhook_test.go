@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWindowsOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a    autoscalingv1.ScheduleSpec
+		b    autoscalingv1.ScheduleSpec
+		want bool
+	}{
+		{
+			name: "disjoint cron windows shorter than 15 minutes",
+			a:    autoscalingv1.ScheduleSpec{Cron: "5 9 * * *", Duration: &metav1.Duration{Duration: 5 * time.Minute}},
+			b:    autoscalingv1.ScheduleSpec{Cron: "20 9 * * *", Duration: &metav1.Duration{Duration: 5 * time.Minute}},
+			want: false,
+		},
+		{
+			name: "overlapping cron windows shorter than 15 minutes",
+			a:    autoscalingv1.ScheduleSpec{Cron: "5 9 * * *", Duration: &metav1.Duration{Duration: 5 * time.Minute}},
+			b:    autoscalingv1.ScheduleSpec{Cron: "8 9 * * *", Duration: &metav1.Duration{Duration: 5 * time.Minute}},
+			want: true,
+		},
+		{
+			name: "weekly windows on different days",
+			a:    autoscalingv1.ScheduleSpec{StartDayOfWeek: 1, StartTime: "09:00", EndTime: "17:00"},
+			b:    autoscalingv1.ScheduleSpec{StartDayOfWeek: 2, StartTime: "09:00", EndTime: "17:00"},
+			want: false,
+		},
+		{
+			name: "weekly window overlapping a daily cron window",
+			a:    autoscalingv1.ScheduleSpec{StartDayOfWeek: 1, StartTime: "09:00", EndTime: "17:00"},
+			b:    autoscalingv1.ScheduleSpec{Cron: "0 12 * * *", Duration: &metav1.Duration{Duration: time.Hour}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := windowsOverlap(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("windowsOverlap: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("windowsOverlap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowListsOverlap(t *testing.T) {
+	base := time.Date(2026, 7, 25, 0, 0, 0, 0, time.UTC)
+
+	a := []window{{Start: base, End: base.Add(time.Hour)}}
+	b := []window{{Start: base.Add(30 * time.Minute), End: base.Add(90 * time.Minute)}}
+
+	if !windowListsOverlap(a, b) {
+		t.Errorf("windowListsOverlap() = false, want true")
+	}
+
+	c := []window{{Start: base.Add(2 * time.Hour), End: base.Add(3 * time.Hour)}}
+
+	if windowListsOverlap(a, c) {
+		t.Errorf("windowListsOverlap() = true, want false")
+	}
+}
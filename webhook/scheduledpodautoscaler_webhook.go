@@ -0,0 +1,44 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	autoscalingv1 "github.com/d-kuro/scheduled-pod-autoscaler/apis/autoscaling/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ScheduledPodAutoscalerValidator rejects ScheduledPodAutoscalers with a malformed
+// HorizontalPodAutoscalerSpec or ScaleTargetRef.
+type ScheduledPodAutoscalerValidator struct {
+	Decoder *admission.Decoder
+}
+
+func (v *ScheduledPodAutoscalerValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	spa := &autoscalingv1.ScheduledPodAutoscaler{}
+	if err := v.Decoder.Decode(req, spa); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := spa.Spec.Validate(); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed("")
+}
@@ -0,0 +1,51 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	mutateSchedulePath   = "/mutate-autoscaling-d-kuro-github-io-v1-schedule"
+	validateSchedulePath = "/validate-autoscaling-d-kuro-github-io-v1-schedule"
+	validateSPAPath      = "/validate-autoscaling-d-kuro-github-io-v1-scheduledpodautoscaler"
+)
+
+// SetupWebhooksWithManager registers the Schedule and ScheduledPodAutoscaler admission webhooks
+// on mgr's webhook server. defaultTimeZone is applied to Schedules that do not set TimeZone.
+func SetupWebhooksWithManager(mgr ctrl.Manager, defaultTimeZone string) error {
+	decoder := admission.NewDecoder(mgr.GetScheme())
+
+	server := mgr.GetWebhookServer()
+
+	server.Register(mutateSchedulePath, &webhook.Admission{
+		Handler: &ScheduleDefaulter{Decoder: decoder, DefaultTimeZone: defaultTimeZone},
+	})
+
+	server.Register(validateSchedulePath, &webhook.Admission{
+		Handler: &ScheduleValidator{Client: mgr.GetClient(), Decoder: decoder},
+	})
+
+	server.Register(validateSPAPath, &webhook.Admission{
+		Handler: &ScheduledPodAutoscalerValidator{Decoder: decoder},
+	})
+
+	return nil
+}